@@ -0,0 +1,58 @@
+package main
+
+import "fmt"
+
+// Backend is a CI system that monocle can pull build state from.
+type Backend interface {
+	// Name returns the short identifier used for the --backend flag and
+	// per-project config (e.g. "circleci", "github").
+	Name() string
+	// ListBuilds returns the recent builds for the given project.
+	ListBuilds(info *projectInfo) ([]buildData, error)
+}
+
+var backendFactories = map[string]func() Backend{}
+
+// registerBackend makes a Backend available by name. Implementations call
+// this from an init() in their own file.
+func registerBackend(name string, factory func() Backend) {
+	backendFactories[name] = factory
+}
+
+// newBackend constructs the Backend registered under name.
+func newBackend(name string) (Backend, error) {
+	factory, ok := backendFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown backend %q (known: %s)", name, knownBackends())
+	}
+	return factory(), nil
+}
+
+func knownBackends() string {
+	names := make([]string, 0, len(backendFactories))
+	for name := range backendFactories {
+		names = append(names, name)
+	}
+	out := ""
+	for i, name := range names {
+		if i > 0 {
+			out += ", "
+		}
+		out += name
+	}
+	return out
+}
+
+// detectBackend guesses a backend name from the project's remote origin
+// host. It falls back to "circleci" when nothing matches, since that was
+// monocle's original and still most common use case.
+func detectBackend(info *projectInfo) string {
+	switch info.host {
+	case "github.com":
+		return "github"
+	case "gitlab.com":
+		return "gitlab"
+	default:
+		return "circleci"
+	}
+}
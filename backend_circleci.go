@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jszwedko/go-circleci"
+)
+
+func init() {
+	registerBackend("circleci", func() Backend { return &circleCIBackend{} })
+}
+
+type circleCIBackend struct{}
+
+func (b *circleCIBackend) Name() string { return "circleci" }
+
+func (b *circleCIBackend) ListBuilds(info *projectInfo) ([]buildData, error) {
+	client := &circleci.Client{Token: circleciToken}
+	builds, err := client.ListRecentBuildsForProject(info.user, info.projectName, info.branch, "", 30, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	data := []buildData{}
+
+	for _, b := range builds {
+		jobName := "build"
+		if b.JobName != nil {
+			jobName = *b.JobName
+		} else if b.Workflows != nil && b.Workflows.JobName != "" {
+			jobName = b.Workflows.JobName
+		}
+
+		duration := "n/a"
+
+		if b.StartTime != nil && b.StopTime != nil {
+			diff := b.StopTime.Sub(*b.StartTime)
+			duration = fmt.Sprint(diff)
+		} else if b.StartTime != nil && b.StopTime == nil {
+			diff := time.Now().Sub(*b.StartTime)
+			duration = fmt.Sprint(diff)
+		}
+
+		workflowID, workflowName := "", ""
+		if b.Workflows != nil {
+			workflowID = b.Workflows.WorkflowId
+			workflowName = b.Workflows.WorkflowName
+		}
+
+		data = append(data, buildData{
+			JobName:      jobName,
+			BuildNum:     fmt.Sprintf("%d", b.BuildNum),
+			Status:       b.Status,
+			Duration:     duration,
+			URL:          b.BuildURL,
+			WorkflowID:   workflowID,
+			WorkflowName: workflowName,
+		})
+	}
+
+	return data, nil
+}
+
+// Rerun triggers a retry of buildNum via CircleCI's rerun endpoint.
+func (b *circleCIBackend) Rerun(info *projectInfo, buildNum string) error {
+	num, err := strconv.Atoi(buildNum)
+	if err != nil {
+		return err
+	}
+
+	client := &circleci.Client{Token: circleciToken}
+	_, err = client.RetryBuild(info.user, info.projectName, num)
+	return err
+}
+
+// Cancel cancels the (presumably still running) build buildNum.
+func (b *circleCIBackend) Cancel(info *projectInfo, buildNum string) error {
+	num, err := strconv.Atoi(buildNum)
+	if err != nil {
+		return err
+	}
+
+	client := &circleci.Client{Token: circleciToken}
+	_, err = client.CancelBuild(info.user, info.projectName, num)
+	return err
+}
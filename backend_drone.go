@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func init() {
+	registerBackend("drone", func() Backend { return &droneBackend{} })
+}
+
+// droneBackend talks to a Drone or Woodpecker server, which share the same
+// builds API shape.
+type droneBackend struct{}
+
+func (b *droneBackend) Name() string { return "drone" }
+
+type droneBuild struct {
+	Number  int64  `json:"number"`
+	Status  string `json:"status"`
+	Event   string `json:"event"`
+	Link    string `json:"link"`
+	Started int64  `json:"started"`
+	Finished int64 `json:"finished"`
+}
+
+func (b *droneBackend) ListBuilds(info *projectInfo) ([]buildData, error) {
+	if len(droneServer) == 0 {
+		return nil, fmt.Errorf("drone backend requires -drone-server (or ENV(DRONE_SERVER))")
+	}
+
+	apiURL := fmt.Sprintf("%s/api/repos/%s/%s/builds", droneServer, info.user, info.projectName)
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(droneToken) > 0 {
+		req.Header.Set("Authorization", "Bearer "+droneToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("drone API returned %s", resp.Status)
+	}
+
+	var builds []droneBuild
+	if err := json.NewDecoder(resp.Body).Decode(&builds); err != nil {
+		return nil, err
+	}
+
+	data := []buildData{}
+	for _, build := range builds {
+		duration := "n/a"
+		if build.Started > 0 {
+			end := build.Finished
+			if end == 0 {
+				end = time.Now().Unix()
+			}
+			duration = fmt.Sprint(time.Duration(end-build.Started) * time.Second)
+		}
+
+		data = append(data, buildData{
+			JobName:  build.Event,
+			BuildNum: fmt.Sprintf("%d", build.Number),
+			Status:   build.Status,
+			Duration: duration,
+			URL:      build.Link,
+		})
+	}
+
+	return data, nil
+}
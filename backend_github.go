@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func init() {
+	registerBackend("github", func() Backend { return &githubBackend{} })
+}
+
+type githubBackend struct{}
+
+func (b *githubBackend) Name() string { return "github" }
+
+type githubRunsResponse struct {
+	WorkflowRuns []struct {
+		ID         int64      `json:"id"`
+		Name       string     `json:"name"`
+		Status     string     `json:"status"`
+		Conclusion string     `json:"conclusion"`
+		HTMLURL    string     `json:"html_url"`
+		RunStartedAt *time.Time `json:"run_started_at"`
+		UpdatedAt  time.Time  `json:"updated_at"`
+	} `json:"workflow_runs"`
+}
+
+func (b *githubBackend) ListBuilds(info *projectInfo) ([]buildData, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/actions/runs?branch=%s&per_page=30", info.user, info.projectName, info.branch)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(githubToken) > 0 {
+		req.Header.Set("Authorization", "token "+githubToken)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("github actions API returned %s", resp.Status)
+	}
+
+	var runs githubRunsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&runs); err != nil {
+		return nil, err
+	}
+
+	data := []buildData{}
+	for _, run := range runs.WorkflowRuns {
+		status := run.Status
+		if run.Status == "completed" {
+			status = githubConclusionToStatus(run.Conclusion)
+		}
+
+		duration := "n/a"
+		if run.RunStartedAt != nil {
+			duration = fmt.Sprint(run.UpdatedAt.Sub(*run.RunStartedAt))
+		}
+
+		data = append(data, buildData{
+			JobName:  run.Name,
+			BuildNum: fmt.Sprintf("%d", run.ID),
+			Status:   status,
+			Duration: duration,
+			URL:      run.HTMLURL,
+		})
+	}
+
+	return data, nil
+}
+
+func githubConclusionToStatus(conclusion string) string {
+	switch conclusion {
+	case "success":
+		return "success"
+	case "failure", "timed_out", "cancelled":
+		return "failed"
+	default:
+		return conclusion
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+func init() {
+	registerBackend("gitlab", func() Backend { return &gitlabBackend{} })
+}
+
+type gitlabBackend struct{}
+
+func (b *gitlabBackend) Name() string { return "gitlab" }
+
+type gitlabPipeline struct {
+	ID        int64      `json:"id"`
+	Status    string     `json:"status"`
+	Ref       string     `json:"ref"`
+	WebURL    string     `json:"web_url"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (b *gitlabBackend) ListBuilds(info *projectInfo) ([]buildData, error) {
+	project := url.QueryEscape(info.user + "/" + info.projectName)
+	apiURL := fmt.Sprintf("https://gitlab.com/api/v4/projects/%s/pipelines?ref=%s&per_page=30", project, info.branch)
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(gitlabToken) > 0 {
+		req.Header.Set("PRIVATE-TOKEN", gitlabToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gitlab pipelines API returned %s", resp.Status)
+	}
+
+	var pipelines []gitlabPipeline
+	if err := json.NewDecoder(resp.Body).Decode(&pipelines); err != nil {
+		return nil, err
+	}
+
+	data := []buildData{}
+	for _, p := range pipelines {
+		data = append(data, buildData{
+			JobName:  "pipeline",
+			BuildNum: fmt.Sprintf("%d", p.ID),
+			Status:   gitlabStatusToStatus(p.Status),
+			Duration: fmt.Sprint(p.UpdatedAt.Sub(p.CreatedAt)),
+			URL:      p.WebURL,
+		})
+	}
+
+	return data, nil
+}
+
+func gitlabStatusToStatus(status string) string {
+	switch status {
+	case "success":
+		return "success"
+	case "failed", "canceled":
+		return "failed"
+	case "running", "pending":
+		return "running"
+	default:
+		return status
+	}
+}
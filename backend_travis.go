@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+func init() {
+	registerBackend("travis", func() Backend { return &travisBackend{} })
+}
+
+type travisBackend struct{}
+
+func (b *travisBackend) Name() string { return "travis" }
+
+type travisBuildsResponse struct {
+	Builds []struct {
+		ID       int64  `json:"id"`
+		State    string `json:"state"`
+		Duration int64  `json:"duration"`
+		Branch   struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"builds"`
+}
+
+func (b *travisBackend) ListBuilds(info *projectInfo) ([]buildData, error) {
+	slug := url.PathEscape(info.user + "/" + info.projectName)
+	apiURL := fmt.Sprintf("https://api.travis-ci.com/repo/%s/builds?branch.name=%s&limit=30", slug, info.branch)
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Travis-API-Version", "3")
+	if len(travisToken) > 0 {
+		req.Header.Set("Authorization", "token "+travisToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("travis API returned %s", resp.Status)
+	}
+
+	var builds travisBuildsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&builds); err != nil {
+		return nil, err
+	}
+
+	data := []buildData{}
+	for _, build := range builds.Builds {
+		data = append(data, buildData{
+			JobName:  "build",
+			BuildNum: fmt.Sprintf("%d", build.ID),
+			Status:   travisStateToStatus(build.State),
+			Duration: fmt.Sprintf("%ds", build.Duration),
+			URL:      fmt.Sprintf("https://travis-ci.com/%s/builds/%d", slug, build.ID),
+		})
+	}
+
+	return data, nil
+}
+
+func travisStateToStatus(state string) string {
+	switch state {
+	case "passed":
+		return "success"
+	case "failed", "errored", "canceled":
+		return "failed"
+	case "started":
+		return "running"
+	default:
+		return state
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ProjectConfig describes one project entry in ~/.monoclerc.yaml, mirroring
+// the per-project "type"/base/secret style used by testres-db's config.
+type ProjectConfig struct {
+	Name           string `yaml:"name"`
+	Backend        string `yaml:"backend"`
+	Branch         string `yaml:"branch"`
+	Base           string `yaml:"base"`
+	Username       string `yaml:"username"`
+	Secret         string `yaml:"secret"`
+	UpdateInterval string `yaml:"updateInterval"`
+}
+
+// Config is the root of ~/.monoclerc.yaml.
+type Config struct {
+	Projects []ProjectConfig `yaml:"projects"`
+}
+
+// defaultConfigPath returns ~/.monoclerc.yaml.
+func defaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".monoclerc.yaml")
+}
+
+// LoadConfig reads and parses a monocle project config file.
+func LoadConfig(path string) (*Config, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(raw, cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// projectInfoFromConfig builds a projectInfo out of a "org/repo"-style
+// ProjectConfig.Name entry, the way parseProjectInfo derives one from git.
+func projectInfoFromConfig(pc *ProjectConfig) *projectInfo {
+	user, repo := splitProjectName(pc.Name)
+
+	branch := pc.Branch
+	if len(branch) == 0 {
+		branch = "master"
+	}
+
+	return &projectInfo{
+		user:        user,
+		projectName: repo,
+		branch:      branch,
+	}
+}
+
+func splitProjectName(name string) (user, repo string) {
+	for i := len(name) - 1; i >= 0; i-- {
+		if name[i] == '/' {
+			return name[:i], name[i+1:]
+		}
+	}
+	return "", name
+}
+
+// resolveBackendName returns pc.Backend, or, when it's left blank, guesses
+// one from pc.Base the same way detectBackend guesses from a git remote's
+// host, falling back to "circleci" like runSingleProject does.
+func resolveBackendName(pc *ProjectConfig) string {
+	if len(pc.Backend) > 0 {
+		return pc.Backend
+	}
+
+	if len(pc.Base) > 0 {
+		if u, err := url.Parse(pc.Base); err == nil && len(u.Host) > 0 {
+			return detectBackend(&projectInfo{host: u.Host})
+		}
+	}
+
+	return "circleci"
+}
+
+// projectUpdateInterval returns pc.UpdateInterval parsed as a duration,
+// falling back to the global -update-interval when the project doesn't
+// override it (or the override doesn't parse).
+func projectUpdateInterval(pc *ProjectConfig) time.Duration {
+	if dur, err := time.ParseDuration(pc.UpdateInterval); err == nil {
+		return dur
+	}
+	if dur, err := time.ParseDuration(updateInterval); err == nil {
+		return dur
+	}
+	return 30 * time.Second
+}
+
+// credential returns the current global token used by the named backend.
+func credential(name string) string {
+	switch name {
+	case "github":
+		return githubToken
+	case "gitlab":
+		return gitlabToken
+	case "drone":
+		return droneToken
+	case "travis":
+		return travisToken
+	default:
+		return circleciToken
+	}
+}
+
+// setCredential points the named backend's global token at value.
+func setCredential(name, value string) {
+	switch name {
+	case "github":
+		githubToken = value
+	case "gitlab":
+		gitlabToken = value
+	case "drone":
+		droneToken = value
+	case "travis":
+		travisToken = value
+	default:
+		circleciToken = value
+	}
+}
+
+// withProjectCredentials runs fn with the backend globals set to pc's own
+// secret/base (when it configured one), restoring whatever was there
+// beforehand once fn returns. Backends read their token from a
+// package-level var, the same one the single-project -circle-token flag
+// fills in, so a dashboard fetching several projects back to back has to
+// save/restore around each one rather than leaving a later project with
+// no secret of its own silently inheriting an earlier one's.
+func withProjectCredentials(pc *ProjectConfig, fn func()) {
+	name := resolveBackendName(pc)
+
+	savedToken := credential(name)
+	defer setCredential(name, savedToken)
+	if len(pc.Secret) > 0 {
+		setCredential(name, pc.Secret)
+	}
+
+	if name == "drone" {
+		savedServer := droneServer
+		defer func() { droneServer = savedServer }()
+		if len(pc.Base) > 0 {
+			droneServer = pc.Base
+		}
+	}
+
+	fn()
+}
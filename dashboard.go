@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gizak/termui"
+)
+
+// projectState is the last successful poll of one dashboard project, kept
+// around so a project with a longer UpdateInterval can keep showing its
+// last result on ticks that don't belong to it.
+type projectState struct {
+	info      *projectInfo
+	builds    []buildData
+	lastFetch time.Time
+}
+
+// dashboard renders one table per configured project, stacked in a Grid,
+// so monocle can be pointed at ~/.monoclerc.yaml instead of a repo clone.
+type dashboard struct {
+	cfg      *Config
+	selected int
+	state    []projectState
+	watchers map[string]*buildWatcher
+}
+
+func newDashboard(cfg *Config) *dashboard {
+	return &dashboard{
+		cfg:      cfg,
+		state:    make([]projectState, len(cfg.Projects)),
+		watchers: map[string]*buildWatcher{},
+	}
+}
+
+// watcherFor returns pc's buildWatcher, creating it on first use. Each
+// project gets its own watcher, keyed by name, so that two projects sharing
+// a backend don't confuse each other's BuildNums for a status transition.
+func (d *dashboard) watcherFor(pc *ProjectConfig) *buildWatcher {
+	w, ok := d.watchers[pc.Name]
+	if !ok {
+		w = newBuildWatcher(NewNotifier())
+		d.watchers[pc.Name] = w
+	}
+	return w
+}
+
+func (d *dashboard) next() {
+	if len(d.cfg.Projects) == 0 {
+		return
+	}
+	d.selected = (d.selected + 1) % len(d.cfg.Projects)
+}
+
+func (d *dashboard) prev() {
+	if len(d.cfg.Projects) == 0 {
+		return
+	}
+	d.selected = (d.selected - 1 + len(d.cfg.Projects)) % len(d.cfg.Projects)
+}
+
+func (d *dashboard) render() error {
+	termui.Body.Rows = nil
+
+	var errs []string
+	for i := range d.cfg.Projects {
+		pc := &d.cfg.Projects[i]
+		st := &d.state[i]
+
+		var fetchErr error
+		if st.info == nil || time.Since(st.lastFetch) >= projectUpdateInterval(pc) {
+			backend, err := newBackend(resolveBackendName(pc))
+			if err != nil {
+				fetchErr = err
+			} else {
+				info := projectInfoFromConfig(pc)
+				var builds []buildData
+				withProjectCredentials(pc, func() {
+					builds, err = backend.ListBuilds(info)
+				})
+				if err != nil {
+					fetchErr = err
+				} else {
+					st.info = info
+					st.builds = builds
+					st.lastFetch = time.Now()
+					if notificationsEnabled {
+						d.watcherFor(pc).observe(builds)
+					}
+				}
+			}
+		}
+
+		if fetchErr != nil {
+			errs = append(errs, fmt.Sprintf("project %s: %v", pc.Name, fetchErr))
+		}
+
+		if st.info == nil {
+			// Never successfully fetched: nothing to show for this project yet.
+			continue
+		}
+
+		table := buildTable(st.info, st.builds)
+		if fetchErr != nil {
+			table.Block.BorderLabel = fmt.Sprintf("%s [stale: %v]", table.Block.BorderLabel, fetchErr)
+			table.Block.BorderFg = termui.ColorRed
+		}
+		if i == d.selected {
+			table.Block.BorderLabel = "» " + table.Block.BorderLabel
+			table.Block.BorderFg = termui.ColorCyan
+		}
+
+		termui.Body.AddRows(termui.NewRow(termui.NewCol(12, 0, table)))
+	}
+
+	termui.Body.Align()
+	termui.Render(termui.Body)
+
+	if statusServer != nil {
+		statusServer.Publish(d.status())
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// status aggregates every project's last poll into a single Status, so
+// "monocle status" has something to report while the dashboard is
+// running too. Each build's JobName is prefixed with its project so
+// entries stay distinguishable once flattened into one list.
+func (d *dashboard) status() Status {
+	names := make([]string, 0, len(d.cfg.Projects))
+	builds := []buildData{}
+
+	for i, pc := range d.cfg.Projects {
+		names = append(names, pc.Name)
+
+		for _, b := range d.state[i].builds {
+			b.JobName = fmt.Sprintf("%s: %s", pc.Name, b.JobName)
+			builds = append(builds, b)
+		}
+	}
+
+	return Status{
+		Project: strings.Join(names, ", "),
+		Builds:  builds,
+	}
+}
@@ -2,10 +2,10 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"github.com/gizak/termui"
-	"github.com/jszwedko/go-circleci"
 	"log"
 	"os"
 	"os/exec"
@@ -22,14 +22,30 @@ const (
 )
 
 var (
-	circleciToken      string
-	circleciInstallURL string
-	updateInterval     string
+	circleciToken        string
+	circleciInstallURL   string
+	updateInterval       string
+	backendName          string
+	configPath           string
+	notificationsEnabled bool
+	githubToken          string
+	gitlabToken          string
+	droneToken           string
+	droneServer          string
+	travisToken          string
 )
 
 func init() {
 	flag.StringVar(&circleciToken, "circle-token", os.Getenv("CIRCLECI_TOKEN"), "CircleCI API Token, or ENV(CIRCLECI_TOKEN)")
 	flag.StringVar(&updateInterval, "update-interval", "30s", "Update updateInterval")
+	flag.StringVar(&backendName, "backend", "", "CI backend to use (circleci, github, gitlab, drone, travis). Defaults to autodetecting from remote.origin.url")
+	flag.StringVar(&configPath, "config", defaultConfigPath(), "Path to a monocle project config (see ~/.monoclerc.yaml) listing multiple projects to watch")
+	flag.BoolVar(&notificationsEnabled, "notify", true, "Send a desktop notification when a build transitions from running to failed, success or fixed")
+	flag.StringVar(&githubToken, "github-token", os.Getenv("GITHUB_TOKEN"), "GitHub API token, or ENV(GITHUB_TOKEN), for the github backend")
+	flag.StringVar(&gitlabToken, "gitlab-token", os.Getenv("GITLAB_TOKEN"), "GitLab API token, or ENV(GITLAB_TOKEN), for the gitlab backend")
+	flag.StringVar(&droneToken, "drone-token", os.Getenv("DRONE_TOKEN"), "Drone/Woodpecker API token, or ENV(DRONE_TOKEN), for the drone backend")
+	flag.StringVar(&droneServer, "drone-server", os.Getenv("DRONE_SERVER"), "Drone/Woodpecker server URL, or ENV(DRONE_SERVER), for the drone backend")
+	flag.StringVar(&travisToken, "travis-token", os.Getenv("TRAVIS_TOKEN"), "Travis CI API token, or ENV(TRAVIS_TOKEN), for the travis backend")
 
 	flag.Usage = func() {
 		fmt.Fprint(os.Stderr, BANNER)
@@ -40,7 +56,7 @@ func init() {
 }
 
 type projectInfo struct {
-	user, projectName, branch string
+	host, user, projectName, branch string
 }
 
 func parseProjectInfo() (*projectInfo, error) {
@@ -61,72 +77,34 @@ func parseProjectInfo() (*projectInfo, error) {
 	originStr := originOutput.String()
 	branchStr := branchOutput.String()
 
-	re := regexp.MustCompile("[a-zA-Z0-9]*\\.[a-zA-Z0-9]*(?::|\\/)(?P<Org>[a-zA-Z0-9\\-\\_]*)\\/(?P<Repo>[a-zA-Z0-9\\-\\_]*)")
+	re := regexp.MustCompile("(?P<Host>[a-zA-Z0-9\\-]*\\.[a-zA-Z0-9\\-\\.]*)(?::|\\/)(?P<Org>[a-zA-Z0-9\\-\\_]*)\\/(?P<Repo>[a-zA-Z0-9\\-\\_]*)")
 
 	matches := re.FindStringSubmatch(originStr)
 
 	return &projectInfo{
-		user:        strings.Trim(matches[1], " \n\t"),
-		projectName: strings.Trim(matches[2], " \n\t"),
+		host:        strings.Trim(matches[1], " \n\t"),
+		user:        strings.Trim(matches[2], " \n\t"),
+		projectName: strings.Trim(matches[3], " \n\t"),
 		branch:      strings.Trim(branchStr, " \n\t"),
 	}, nil
 }
 
 type buildData struct {
-	JobName  string
-	BuildNum string
-	Status   string
-	URL      string
-	Duration string
+	JobName      string
+	BuildNum     string
+	Status       string
+	URL          string
+	Duration     string
+	WorkflowID   string
+	WorkflowName string
 }
 
-func loadBuilds(info *projectInfo) []buildData {
-	client := &circleci.Client{Token: circleciToken}
-	builds, _ := client.ListRecentBuildsForProject(info.user, info.projectName, info.branch, "", 30, 0)
-
-	data := []buildData{}
-
-	for _, b := range builds {
-		jobName := "build"
-		if b.JobName != nil {
-			jobName = *b.JobName
-		} else if b.Workflows != nil && b.Workflows.JobName != "" {
-			jobName = b.Workflows.JobName
-		}
-
-		duration := "n/a"
-
-		if b.StartTime != nil && b.StopTime != nil {
-			diff := b.StopTime.Sub(*b.StartTime)
-			duration = fmt.Sprint(diff)
-		} else if b.StartTime != nil && b.StopTime == nil {
-			diff := time.Now().Sub(*b.StartTime)
-			duration = fmt.Sprint(diff)
-		}
-
-		data = append(data, buildData{
-			JobName:  jobName,
-			BuildNum: fmt.Sprintf("%d", b.BuildNum),
-			Status:   b.Status,
-			Duration: duration,
-			URL:      b.BuildURL,
-		})
-	}
-
-	return data
-}
-
-func runCircleCIView() (*termui.Table, error) {
-	info, err := parseProjectInfo()
-	if err != nil {
-		return nil, err
-	}
+func buildTable(info *projectInfo, builds []buildData) *termui.Table {
 	table := termui.NewTable()
 	rows := [][]string{
 		{"build_num", "job", "state", "duration", "url"},
 	}
 
-	builds := loadBuilds(info)
 	redRows := []int{}
 	greenRows := []int{}
 	for i, b := range builds {
@@ -159,65 +137,333 @@ func runCircleCIView() (*termui.Table, error) {
 		table.FgColors[br] = termui.ColorGreen
 	}
 
-	return table, nil
+	return table
 }
 
-func setupCircleCIView() {
-	table, err := runCircleCIView()
+func runView(backend Backend, view *tableView) error {
+	info, err := parseProjectInfo()
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+
+	builds, err := backend.ListBuilds(info)
+	if err != nil {
+		return err
+	}
+
+	if statusServer != nil {
+		statusServer.Publish(Status{
+			Project: fmt.Sprintf("%s/%s", info.user, info.projectName),
+			Builds:  builds,
+		})
 	}
-	if table != nil {
-		termui.Render(table)
+
+	if watcher != nil {
+		watcher.observe(builds)
+	}
+
+	view.setBuilds(info, builds)
+	return nil
+}
+
+// statusServer, when non-nil, publishes every polled build list over
+// monocle's status socket for the "monocle status" subcommand and other
+// status-line integrations to read without launching the TUI.
+var statusServer *Server
+
+// watcher, when non-nil, fires desktop notifications on build state
+// transitions observed between successive polls.
+var watcher *buildWatcher
+
+func fetchView(backend Backend, view *tableView) {
+	if err := runView(backend, view); err != nil {
+		log.Fatal(err)
 	}
 }
 
 func main() {
-	if len(circleciToken) == 0 {
-		log.Fatalf("a circleci token is required")
+	if flag.Arg(0) == "status" {
+		printStatus()
+		return
+	}
+
+	if server, err := NewServer(); err != nil {
+		log.Printf("status socket disabled: %v", err)
+	} else {
+		statusServer = server
+		defer statusServer.Close()
+	}
+
+	var cfg *Config
+	if len(configPath) > 0 {
+		if loaded, err := LoadConfig(configPath); err == nil && len(loaded.Projects) > 0 {
+			cfg = loaded
+		}
+	}
+
+	if cfg != nil {
+		runDashboard(cfg)
+		return
 	}
 
-	var ticker *time.Ticker
+	runSingleProject()
+}
+
+// runSingleProject is monocle's original mode: derive the project from the
+// current repo's git remote and watch it alone.
+func runSingleProject() {
+	name := backendName
+	if len(name) == 0 {
+		info, err := parseProjectInfo()
+		if err != nil {
+			log.Fatalf("detecting backend failed, pass -backend explicitly: %v", err)
+		}
+		name = detectBackend(info)
+	}
 
-	dur, err := time.ParseDuration(updateInterval)
+	backend, err := newBackend(name)
 	if err != nil {
-		log.Fatalf("parsing %s as duration failed: %v", updateInterval, err)
+		log.Fatal(err)
+	}
+
+	if notificationsEnabled {
+		watcher = newBuildWatcher(NewNotifier())
+	}
+
+	ticker := newUpdateTicker(updateInterval)
+
+	if err := termui.Init(); err != nil {
+		log.Fatalf("initializing termui failed: %v", err)
+	}
+	defer termui.Close()
+
+	view := &tableView{}
+	workflowMode := false
+
+	redraw := func() {
+		if workflowMode {
+			renderWorkflowView(view.info, view.builds)
+			return
+		}
+		termui.Render(view.render())
+	}
+
+	go func() {
+		fetchView(backend, view)
+		redraw()
+	}()
+
+	// press ctrl-c to quit (q quits too, but is handled in the actions
+	// map below since it can also be typed into a filter).
+	termui.Handle("/sys/kbd/C-c", func(termui.Event) {
+		ticker.Stop()
+		termui.StopLoop()
+	})
+
+	// Single-letter actions. Each of these is also a character a filter
+	// query could legitimately contain (e.g. filtering on "running" or
+	// "cancelled"), so while a filter is being composed the keystroke is
+	// appended to it instead of firing the action - termui's EvtStream
+	// only ever dispatches a key to one handler, so this has to be
+	// checked here rather than relying on a separate catch-all.
+	actions := map[string]func(){
+		"q": func() {
+			ticker.Stop()
+			termui.StopLoop()
+		},
+		"j": func() { view.move(1) },
+		"k": func() { view.move(-1) },
+		"o": func() {
+			if err := view.open(); err != nil {
+				log.Print(err)
+			}
+		},
+		"r": func() {
+			if err := view.rerun(backend); err != nil {
+				log.Print(err)
+			}
+			fetchView(backend, view)
+		},
+		"c": func() {
+			if err := view.cancel(backend); err != nil {
+				log.Print(err)
+			}
+			fetchView(backend, view)
+		},
+		"w": func() { workflowMode = !workflowMode },
 	}
 
-	ticker = time.NewTicker(dur)
+	for key, action := range actions {
+		key, action := key, action
+		termui.Handle("/sys/kbd/"+key, func(termui.Event) {
+			if view.filtering {
+				view.appendFilter(key)
+			} else {
+				action()
+			}
+			redraw()
+		})
+	}
+
+	// "/" can't get its own "/sys/kbd//" registration: termui cleans that
+	// path down to "/sys/kbd", the exact path the catch-all below uses,
+	// and since Handlers is a plain map the second registration silently
+	// wins over the first. So "/" is handled inside the catch-all itself
+	// instead of through the per-key loop above.
+
+	// The arrow keys mirror j/k, but aren't printable so they're left out
+	// of the filter-composing map above.
+	termui.Handle("/sys/kbd/<down>", func(termui.Event) {
+		if !view.filtering {
+			view.move(1)
+		}
+		redraw()
+	})
+	termui.Handle("/sys/kbd/<up>", func(termui.Event) {
+		if !view.filtering {
+			view.move(-1)
+		}
+		redraw()
+	})
+
+	// enter/escape/backspace drive composing and leaving the filter.
+	termui.Handle("/sys/kbd/<enter>", func(termui.Event) {
+		view.stopFilter()
+		redraw()
+	})
+	termui.Handle("/sys/kbd/<escape>", func(termui.Event) {
+		view.clearFilter()
+		redraw()
+	})
+	termui.Handle("/sys/kbd/<backspace>", func(termui.Event) {
+		if view.filtering {
+			view.backspaceFilter()
+			redraw()
+		}
+	})
+
+	// "/" starts filtering (or, mid-filter, is appended like any other
+	// character); any other printable key is appended only while a
+	// filter is being composed.
+	termui.Handle("/sys/kbd", func(e termui.Event) {
+		kbd, ok := e.Data.(termui.EvtKbd)
+		if !ok || len(kbd.KeyStr) != 1 {
+			return
+		}
+
+		if kbd.KeyStr == "/" {
+			if view.filtering {
+				view.appendFilter("/")
+			} else {
+				view.startFilter()
+			}
+			redraw()
+			return
+		}
+
+		if !view.filtering {
+			return
+		}
+		if _, isAction := actions[kbd.KeyStr]; isAction {
+			return
+		}
+		view.appendFilter(kbd.KeyStr)
+		redraw()
+	})
+
+	termui.Handle("/sys/wnd/resize", func(e termui.Event) {
+		termui.Clear()
+		redraw()
+	})
+
+	// Update on an interval
+	go func() {
+		for range ticker.C {
+			fetchView(backend, view)
+			redraw()
+		}
+	}()
+
+	// Start the loop.
+	termui.Loop()
+}
+
+// runDashboard watches every project listed in a ~/.monoclerc.yaml config,
+// rendering one table per project in a stacked termui Grid.
+func runDashboard(cfg *Config) {
+	d := newDashboard(cfg)
+
+	ticker := newUpdateTicker(updateInterval)
 
 	if err := termui.Init(); err != nil {
 		log.Fatalf("initializing termui failed: %v", err)
 	}
 	defer termui.Close()
 
-	go setupCircleCIView()
+	render := func() {
+		if err := d.render(); err != nil {
+			log.Print(err)
+		}
+	}
+
+	go render()
 
-	// press q to quit
 	termui.Handle("/sys/kbd/q", func(termui.Event) {
 		ticker.Stop()
 		termui.StopLoop()
 	})
 
-	// Or press ctrl-c.
 	termui.Handle("/sys/kbd/C-c", func(termui.Event) {
 		ticker.Stop()
 		termui.StopLoop()
 	})
 
+	// cycle the highlighted project
+	termui.Handle("/sys/kbd/n", func(termui.Event) {
+		d.next()
+		render()
+	})
+	termui.Handle("/sys/kbd/p", func(termui.Event) {
+		d.prev()
+		render()
+	})
+
 	termui.Handle("/sys/wnd/resize", func(e termui.Event) {
 		termui.Clear()
-		setupCircleCIView()
-		termui.Render()
+		render()
 	})
 
-	// Update on an interval
 	go func() {
 		for range ticker.C {
-			setupCircleCIView()
+			render()
 		}
 	}()
 
-	// Start the loop.
 	termui.Loop()
 }
+
+func newUpdateTicker(interval string) *time.Ticker {
+	dur, err := time.ParseDuration(interval)
+	if err != nil {
+		log.Fatalf("parsing %s as duration failed: %v", interval, err)
+	}
+	return time.NewTicker(dur)
+}
+
+// printStatus implements the "monocle status" subcommand: it connects to a
+// running monocle daemon's socket and prints its latest published Status
+// (the project and every build from its last poll) as JSON, without
+// launching the TUI. It prints an empty status if no daemon runs.
+func printStatus() {
+	status, err := NewClient().Status()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out, err := json.Marshal(status)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(string(out))
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"runtime"
+)
+
+// Notifier sends a desktop notification for a build state transition.
+type Notifier interface {
+	Notify(title, body string) error
+}
+
+// NewNotifier returns the best Notifier for the current OS, falling back
+// to a no-op when no notification backend is available.
+func NewNotifier() Notifier {
+	switch runtime.GOOS {
+	case "linux":
+		return &xnotifier{}
+	case "darwin":
+		return &macNotifier{}
+	default:
+		return &noopNotifier{}
+	}
+}
+
+// xnotifier sends notifications via libnotify's notify-send, following
+// pomo's NewXnotifier pattern.
+type xnotifier struct{}
+
+func (n *xnotifier) Notify(title, body string) error {
+	return exec.Command("notify-send", title, body).Run()
+}
+
+// macNotifier sends notifications via terminal-notifier, falling back to
+// osascript if it isn't installed.
+type macNotifier struct{}
+
+func (n *macNotifier) Notify(title, body string) error {
+	if _, err := exec.LookPath("terminal-notifier"); err == nil {
+		return exec.Command("terminal-notifier", "-title", title, "-message", body).Run()
+	}
+	script := fmt.Sprintf("display notification %q with title %q", body, title)
+	return exec.Command("osascript", "-e", script).Run()
+}
+
+// noopNotifier is used on platforms with no known notification backend.
+type noopNotifier struct{}
+
+func (n *noopNotifier) Notify(title, body string) error { return nil }
+
+// buildWatcher tracks the last seen status of each build by BuildNum and
+// fires a Notifier when a build transitions out of "running" into
+// "failed", "success" or "fixed", so monocle can be used as a background
+// monitor rather than requiring active viewing. Other statuses a build
+// leaves "running" for (e.g. CircleCI's "canceled") do not notify.
+type buildWatcher struct {
+	notifier Notifier
+	prior    map[string]string
+}
+
+func newBuildWatcher(notifier Notifier) *buildWatcher {
+	return &buildWatcher{notifier: notifier, prior: map[string]string{}}
+}
+
+func (w *buildWatcher) observe(builds []buildData) {
+	for _, b := range builds {
+		prev, seen := w.prior[b.BuildNum]
+		w.prior[b.BuildNum] = b.Status
+
+		if !seen || prev != "running" {
+			continue
+		}
+
+		switch b.Status {
+		case "failed", "success", "fixed":
+			title := fmt.Sprintf("%s #%s %s", b.JobName, b.BuildNum, b.Status)
+			body := fmt.Sprintf("took %s\n%s", b.Duration, b.URL)
+			if err := w.notifier.Notify(title, body); err != nil {
+				log.Print(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// socketPath returns the unix socket monocle's daemon listens on and its
+// status subcommand connects to, preferring $XDG_RUNTIME_DIR.
+func socketPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); len(dir) > 0 {
+		return filepath.Join(dir, "monocle.sock")
+	}
+	return filepath.Join(os.TempDir(), "monocle.sock")
+}
+
+// Status is the snapshot monocle publishes to socket subscribers.
+type Status struct {
+	Project string      `json:"project"`
+	Builds  []buildData `json:"builds"`
+}
+
+// Server publishes the latest Status over a unix socket so other tools
+// (tmux, polybar, i3blocks, CI hooks) can poll monocle without a TUI.
+type Server struct {
+	listener net.Listener
+	path     string
+
+	mu     sync.Mutex
+	latest Status
+}
+
+// NewServer starts listening on monocle's status socket.
+func NewServer() (*Server, error) {
+	path := socketPath()
+	os.Remove(path)
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{listener: listener, path: path}
+	go s.serve()
+	return s, nil
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	s.mu.Lock()
+	latest := s.latest
+	s.mu.Unlock()
+
+	json.NewEncoder(conn).Encode(latest)
+}
+
+// Publish replaces the status served to new connections.
+func (s *Server) Publish(status Status) {
+	s.mu.Lock()
+	s.latest = status
+	s.mu.Unlock()
+}
+
+// Close shuts down the listener and removes the socket file.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	os.Remove(s.path)
+	return err
+}
+
+// Client queries a running monocle daemon's socket for its latest status.
+type Client struct {
+	path string
+}
+
+// NewClient returns a client for monocle's status socket.
+func NewClient() *Client {
+	return &Client{path: socketPath()}
+}
+
+// Status connects to the daemon and returns its latest snapshot. If no
+// daemon is listening it returns an empty Status rather than erroring, so
+// status-line integrations fail quietly instead of spamming errors.
+func (c *Client) Status() (Status, error) {
+	conn, err := net.DialTimeout("unix", c.path, 500*time.Millisecond)
+	if err != nil {
+		return Status{}, nil
+	}
+	defer conn.Close()
+
+	var status Status
+	if err := json.NewDecoder(conn).Decode(&status); err != nil {
+		return Status{}, err
+	}
+	return status, nil
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/gizak/termui"
+)
+
+// MutableBackend is implemented by backends that can trigger a rerun or
+// cancel a running build, turning monocle from a passive viewer into a
+// lightweight control panel.
+type MutableBackend interface {
+	Backend
+	Rerun(info *projectInfo, buildNum string) error
+	Cancel(info *projectInfo, buildNum string) error
+}
+
+// tableView holds the interactive state for the single-project table: the
+// full list of polled builds, the active job/status filter, and which row
+// is highlighted.
+type tableView struct {
+	info      *projectInfo
+	builds    []buildData
+	selected  int
+	filter    string
+	filtering bool
+}
+
+func (v *tableView) setBuilds(info *projectInfo, builds []buildData) {
+	v.info = info
+	v.builds = builds
+	if v.selected >= len(v.filtered()) {
+		v.selected = 0
+	}
+}
+
+func (v *tableView) filtered() []buildData {
+	if len(v.filter) == 0 {
+		return v.builds
+	}
+
+	out := []buildData{}
+	needle := strings.ToLower(v.filter)
+	for _, b := range v.builds {
+		if strings.Contains(strings.ToLower(b.JobName), needle) || strings.Contains(strings.ToLower(b.Status), needle) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+func (v *tableView) move(delta int) {
+	builds := v.filtered()
+	if len(builds) == 0 {
+		return
+	}
+	v.selected = ((v.selected+delta)%len(builds) + len(builds)) % len(builds)
+}
+
+func (v *tableView) selectedBuild() (buildData, bool) {
+	builds := v.filtered()
+	if v.selected < 0 || v.selected >= len(builds) {
+		return buildData{}, false
+	}
+	return builds[v.selected], true
+}
+
+// render draws the current filtered build list as a table, highlighting
+// the selected row.
+func (v *tableView) render() *termui.Table {
+	builds := v.filtered()
+	table := buildTable(v.info, builds)
+
+	if v.selected >= 0 && v.selected < len(builds) {
+		table.BgColors[v.selected+1] = termui.ColorBlue
+	}
+
+	if v.filtering || len(v.filter) > 0 {
+		table.Block.BorderLabel = fmt.Sprintf("%s (filter: %s)", table.Block.BorderLabel, v.filter)
+	}
+
+	return table
+}
+
+// open launches the selected build's URL in the system's default browser.
+func (v *tableView) open() error {
+	b, ok := v.selectedBuild()
+	if !ok || len(b.URL) == 0 {
+		return nil
+	}
+	return openURL(b.URL)
+}
+
+func openURL(url string) error {
+	opener := "xdg-open"
+	if runtime.GOOS == "darwin" {
+		opener = "open"
+	}
+	return exec.Command(opener, url).Start()
+}
+
+// rerun reruns the selected build, if the active backend supports it.
+func (v *tableView) rerun(backend Backend) error {
+	mutable, ok := backend.(MutableBackend)
+	if !ok {
+		return fmt.Errorf("%s backend does not support rerunning builds", backend.Name())
+	}
+	b, ok := v.selectedBuild()
+	if !ok {
+		return nil
+	}
+	return mutable.Rerun(v.info, b.BuildNum)
+}
+
+// cancel cancels the selected build, if the active backend supports it.
+func (v *tableView) cancel(backend Backend) error {
+	mutable, ok := backend.(MutableBackend)
+	if !ok {
+		return fmt.Errorf("%s backend does not support cancelling builds", backend.Name())
+	}
+	b, ok := v.selectedBuild()
+	if !ok {
+		return nil
+	}
+	return mutable.Cancel(v.info, b.BuildNum)
+}
+
+// startFilter, appendFilter, backspaceFilter, and clearFilter drive the
+// "/" filter-by-job-name-or-status input mode.
+func (v *tableView) startFilter() {
+	v.filtering = true
+}
+
+func (v *tableView) appendFilter(r string) {
+	v.filter += r
+	v.selected = 0
+}
+
+func (v *tableView) backspaceFilter() {
+	if len(v.filter) == 0 {
+		return
+	}
+	v.filter = v.filter[:len(v.filter)-1]
+	v.selected = 0
+}
+
+func (v *tableView) stopFilter() {
+	v.filtering = false
+}
+
+func (v *tableView) clearFilter() {
+	v.filter = ""
+	v.filtering = false
+	v.selected = 0
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gizak/termui"
+)
+
+// workflowGroup is one CircleCI workflow's jobs, in DAG order as returned
+// by the builds API (consecutive builds sharing a WorkflowID belong to the
+// same run).
+type workflowGroup struct {
+	id     string
+	name   string
+	builds []buildData
+}
+
+// groupByWorkflow buckets builds into their workflow runs, preserving the
+// order workflows first appear in. Builds with no WorkflowID (plain,
+// non-workflow CircleCI 1.0-style builds) are grouped under "ungrouped".
+func groupByWorkflow(builds []buildData) []workflowGroup {
+	groups := []workflowGroup{}
+	index := map[string]int{}
+
+	for _, b := range builds {
+		id := b.WorkflowID
+		name := b.WorkflowName
+		if len(id) == 0 {
+			id = "ungrouped"
+			name = "ungrouped"
+		}
+
+		i, ok := index[id]
+		if !ok {
+			i = len(groups)
+			index[id] = i
+			groups = append(groups, workflowGroup{id: id, name: name})
+		}
+
+		groups[i].builds = append(groups[i].builds, b)
+	}
+
+	return groups
+}
+
+// aggregateStatus rolls a workflow's job statuses up to a single status:
+// failed if any job failed, running if any job is still running, else
+// success.
+func aggregateStatus(builds []buildData) string {
+	status := "success"
+	for _, b := range builds {
+		switch b.Status {
+		case "failed":
+			return "failed"
+		case "running":
+			status = "running"
+		}
+	}
+	return status
+}
+
+// renderWorkflowView renders builds grouped by workflow, one termui.List
+// per workflow stacked in a Grid, with jobs indented under a tree glyph.
+func renderWorkflowView(info *projectInfo, builds []buildData) {
+	termui.Body.Rows = nil
+
+	for _, group := range groupByWorkflow(builds) {
+		list := termui.NewList()
+		list.Items = workflowListItems(group.builds)
+		list.Border = true
+		list.Block.BorderLabel = fmt.Sprintf("%s [%s]", group.name, aggregateStatus(group.builds))
+		list.Height = len(list.Items) + 2
+
+		switch aggregateStatus(group.builds) {
+		case "failed":
+			list.Block.BorderFg = termui.ColorRed
+		case "running":
+			list.Block.BorderFg = termui.ColorYellow
+		default:
+			list.Block.BorderFg = termui.ColorGreen
+		}
+
+		termui.Body.AddRows(termui.NewRow(termui.NewCol(12, 0, list)))
+	}
+
+	termui.Body.Align()
+	termui.Render(termui.Body)
+}
+
+func workflowListItems(builds []buildData) []string {
+	items := make([]string, len(builds))
+	for i, b := range builds {
+		glyph := "├─"
+		if i == len(builds)-1 {
+			glyph = "└─"
+		}
+		items[i] = fmt.Sprintf("%s %s #%s (%s) %s", glyph, b.JobName, b.BuildNum, b.Status, b.Duration)
+	}
+	return items
+}